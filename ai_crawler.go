@@ -0,0 +1,32 @@
+package useragent
+
+// CategoryAICrawler is the bad-bots registry category for AI/LLM crawlers,
+// e.g. GPTBot, ChatGPT-User, OAI-SearchBot, ClaudeBot, anthropic-ai,
+// PerplexityBot, Google-Extended, CCBot, Bytespider, Amazonbot,
+// Meta-ExternalAgent, and Applebot-Extended.
+const CategoryAICrawler = "ai"
+
+// AI crawler purposes recognized by the registry's purpose field.
+const (
+	PurposeTraining  = "training"
+	PurposeRetrieval = "retrieval"
+	PurposeAgent     = "agent"
+)
+
+// IsAICrawler returns true if this user agent was classified as a known AI
+// crawler, i.e. its matched bad-bots registry entry has category
+// CategoryAICrawler.
+func (p *UserAgent) IsAICrawler() bool {
+	return p.botInfo != nil && p.botInfo.Category == CategoryAICrawler
+}
+
+// AICrawlerPurpose returns the purpose declared for the matched AI crawler
+// in the registry (one of PurposeTraining, PurposeRetrieval, PurposeAgent),
+// or "" if this user agent isn't a known AI crawler or its entry doesn't
+// declare a purpose.
+func (p *UserAgent) AICrawlerPurpose() string {
+	if !p.IsAICrawler() {
+		return ""
+	}
+	return p.botInfo.Purpose
+}
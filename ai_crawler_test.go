@@ -0,0 +1,45 @@
+package useragent
+
+import "testing"
+
+func TestIsAICrawler(t *testing.T) {
+	cases := []struct {
+		name string
+		info *BotInfo
+		want bool
+	}{
+		{"nil botInfo", nil, false},
+		{"non-AI category", &BotInfo{Category: "seo"}, false},
+		{"AI category", &BotInfo{Category: CategoryAICrawler}, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ua := &UserAgent{botInfo: c.info}
+			if got := ua.IsAICrawler(); got != c.want {
+				t.Errorf("IsAICrawler() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestAICrawlerPurpose(t *testing.T) {
+	cases := []struct {
+		name string
+		info *BotInfo
+		want string
+	}{
+		{"not an AI crawler", &BotInfo{Category: "seo", Purpose: PurposeTraining}, ""},
+		{"AI crawler with purpose", &BotInfo{Category: CategoryAICrawler, Purpose: PurposeRetrieval}, PurposeRetrieval},
+		{"AI crawler without purpose", &BotInfo{Category: CategoryAICrawler}, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ua := &UserAgent{botInfo: c.info}
+			if got := ua.AICrawlerPurpose(); got != c.want {
+				t.Errorf("AICrawlerPurpose() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
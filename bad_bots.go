@@ -1,26 +1,76 @@
 package useragent
 
 import (
-	"gopkg.in/yaml.v3"
+	"io"
 	"os"
+
+	"gopkg.in/yaml.v3"
 )
 
+// BotInfo describes a single entry in the bad-bots registry: a canonical
+// identity plus the metadata needed to group aliases, filter by category,
+// and (optionally) verify the bot's origin.
+type BotInfo struct {
+	ID        int      `yaml:"id"`
+	Canonical string   `yaml:"canonical"`
+	Aliases   []string `yaml:"aliases"`
+	Category  string   `yaml:"category"`
+	Operator  string   `yaml:"operator"`
+	URL       string   `yaml:"url"`
+	Verify    string   `yaml:"verify"`
+	// Purpose further classifies bots in the CategoryAICrawler category,
+	// e.g. PurposeTraining, PurposeRetrieval, or PurposeAgent.
+	Purpose string `yaml:"purpose"`
+	// VerifySuffixes lists the PTR hostname suffixes that are accepted as
+	// legitimate for this bot when Verify is "reverse_dns", e.g.
+	// ".googlebot.com" or ".search.msn.com".
+	VerifySuffixes []string `yaml:"verify_suffixes"`
+}
+
+// names returns the canonical name followed by all known aliases, the full
+// set of strings that identify this bot in the wild.
+func (b *BotInfo) names() []string {
+	return append([]string{b.Canonical}, b.Aliases...)
+}
+
+// BadBotsList is the on-disk shape of the bad-bots registry. Bots is the
+// current, structured format; Flat is the legacy flat list and is only
+// consulted when Bots is absent.
 type BadBotsList struct {
-	Bots []string `yaml:"nginx_bad_agents_default"`
+	Bots []BotInfo `yaml:"bots"`
+	Flat []string  `yaml:"nginx_bad_agents_default"`
 }
 
-// LoadBadBots loads the list of bad bot substrings from a YAML file.
-func LoadBadBots(path string) ([]string, error) {
+// LoadBadBots loads the registry of known bad bots from a YAML file. It
+// understands the structured "bots:" format; if that key is absent, it
+// falls back to the legacy flat "nginx_bad_agents_default:" list and
+// upgrades each entry into a BotInfo with only Canonical set.
+func LoadBadBots(path string) ([]BotInfo, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
 	defer f.Close()
+	return decodeBadBotsList(f)
+}
 
+// decodeBadBotsList decodes a bad-bots registry from YAML read from r,
+// applying the same structured/legacy-flat fallback as LoadBadBots. It's
+// shared with BotListProvider implementations that fetch the registry from
+// somewhere other than a local file (e.g. HTTPProvider).
+func decodeBadBotsList(r io.Reader) ([]BotInfo, error) {
 	var list BadBotsList
-	dec := yaml.NewDecoder(f)
-	if err := dec.Decode(&list); err != nil {
+	if err := yaml.NewDecoder(r).Decode(&list); err != nil {
 		return nil, err
 	}
-	return list.Bots, nil
+
+	if len(list.Bots) > 0 {
+		return list.Bots, nil
+	}
+
+	bots := make([]BotInfo, 0, len(list.Flat))
+	for _, name := range list.Flat {
+		bots = append(bots, BotInfo{Canonical: name})
+	}
+	return bots, nil
 }
@@ -0,0 +1,78 @@
+package useragent
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeBadBotsListStructured(t *testing.T) {
+	yaml := `
+bots:
+  - id: 55
+    canonical: AspiegelBot
+    aliases: [PetalBot]
+    category: seo
+    operator: Huawei
+`
+	bots, err := decodeBadBotsList(strings.NewReader(yaml))
+	if err != nil {
+		t.Fatalf("decodeBadBotsList: %v", err)
+	}
+	if len(bots) != 1 {
+		t.Fatalf("got %d bots, want 1", len(bots))
+	}
+	got := bots[0]
+	if got.ID != 55 || got.Canonical != "AspiegelBot" || got.Category != "seo" {
+		t.Fatalf("unexpected entry: %+v", got)
+	}
+	if len(got.Aliases) != 1 || got.Aliases[0] != "PetalBot" {
+		t.Fatalf("unexpected aliases: %+v", got.Aliases)
+	}
+}
+
+func TestDecodeBadBotsListLegacyFlatFallback(t *testing.T) {
+	yaml := `
+nginx_bad_agents_default:
+  - BadBot1
+  - BadBot2
+`
+	bots, err := decodeBadBotsList(strings.NewReader(yaml))
+	if err != nil {
+		t.Fatalf("decodeBadBotsList: %v", err)
+	}
+	if len(bots) != 2 {
+		t.Fatalf("got %d bots, want 2", len(bots))
+	}
+	for i, want := range []string{"BadBot1", "BadBot2"} {
+		if bots[i].Canonical != want {
+			t.Errorf("bots[%d].Canonical = %q, want %q", i, bots[i].Canonical, want)
+		}
+		if len(bots[i].Aliases) != 0 {
+			t.Errorf("bots[%d].Aliases = %v, want none", i, bots[i].Aliases)
+		}
+	}
+}
+
+func TestDecodeBadBotsListStructuredTakesPrecedence(t *testing.T) {
+	yaml := `
+bots:
+  - canonical: RealBot
+nginx_bad_agents_default:
+  - LegacyBot
+`
+	bots, err := decodeBadBotsList(strings.NewReader(yaml))
+	if err != nil {
+		t.Fatalf("decodeBadBotsList: %v", err)
+	}
+	if len(bots) != 1 || bots[0].Canonical != "RealBot" {
+		t.Fatalf("expected only the structured entry, got %+v", bots)
+	}
+}
+
+func TestBotInfoNames(t *testing.T) {
+	b := &BotInfo{Canonical: "AspiegelBot", Aliases: []string{"PetalBot"}}
+	names := b.names()
+	if len(names) != 2 || names[0] != "AspiegelBot" || names[1] != "PetalBot" {
+		t.Fatalf("unexpected names: %v", names)
+	}
+}
@@ -7,27 +7,10 @@ package useragent
 import (
 	"regexp"
 	"strings"
-	"sync"
 )
 
 var botFromSiteRegexp = regexp.MustCompile(`http[s]?://.+\.\w+`)
 
-var (
-	badBotsList []string
-	badBotsOnce sync.Once
-)
-
-// LoadBadBotsYAML loads the bad bots list from YAML only once (thread-safe).
-func LoadBadBotsYAML() []string {
-	badBotsOnce.Do(func() {
-		list, err := LoadBadBots("bad_bots.yaml")
-		if err == nil {
-			badBotsList = list
-		}
-	})
-	return badBotsList
-}
-
 // Get the name of the bot from the website that may be in the given comment. If
 // there is no website in the comment, then an empty string is returned.
 func getFromSite(comment []string) string {
@@ -113,6 +96,27 @@ func (p *UserAgent) setSimple(name, version string, bot bool) {
 	p.localization = ""
 }
 
+// setBot marks the receiver as the given registry entry. It records the
+// alias exactly as it was seen in the user agent string on browser.Name
+// (falling back to the canonical name), while also keeping the canonical
+// registry entry itself so that aliases of the same bot (e.g. PetalBot and
+// AspiegelBot) can be grouped under one BotInfo via UserAgent.BotInfo.
+func (p *UserAgent) setBot(info *BotInfo, aliasSeen, version string) {
+	name := aliasSeen
+	if name == "" {
+		name = info.Canonical
+	}
+	p.setSimple(name, version, true)
+	p.botInfo = info
+}
+
+// BotInfo returns the bad-bots registry entry that this user agent matched,
+// or nil if it wasn't classified as a known bad bot, or matched through a
+// path (such as getFromSite) that has no registry entry to report.
+func (p *UserAgent) BotInfo() *BotInfo {
+	return p.botInfo
+}
+
 // Fix some values for some weird browsers.
 func (p *UserAgent) fixOther(sections []section) {
 	if len(sections) > 0 {
@@ -122,14 +126,51 @@ func (p *UserAgent) fixOther(sections []section) {
 	}
 }
 
-// Checks if the given string contains any known bad bot substring (case-insensitive).
-func isKnownBadBot(s string) bool {
-	for _, bot := range LoadBadBotsYAML() {
-		if strings.Contains(strings.ToLower(s), strings.ToLower(bot)) {
-			return true
+// Checks the given string against the bad-bots registry (case-insensitive).
+// Returns the matching entry and the exact substring of s that matched,
+// preserving its original case, or (nil, "") if nothing matched.
+func isKnownBadBot(s string) (*BotInfo, string) {
+	low := strings.ToLower(s)
+	registry := LoadBadBotsYAML()
+	for i := range registry {
+		for _, name := range registry[i].names() {
+			if name == "" {
+				continue
+			}
+			idx := strings.Index(low, strings.ToLower(name))
+			if idx != -1 {
+				return &registry[i], s[idx : idx+len(name)]
+			}
 		}
 	}
-	return false
+	return nil, ""
+}
+
+// Looks for a "name/version" bad-bot signature inside the given comment
+// field. Returns the matching registry entry, the version that followed the
+// match (if any), and the matched text with its original case as it
+// appeared in the user agent string. If nothing matched, info is nil.
+func matchKnownBot(c string) (info *BotInfo, version, original string) {
+	info, original = isKnownBadBot(c)
+	if info == nil {
+		return nil, "", ""
+	}
+
+	idx := strings.Index(c, original)
+	// Try to extract the version if present (e.g. GPTBot/1.3) by looking
+	// for a botname/version pattern up to the next space or semicolon.
+	parts := strings.SplitN(c[idx:], "/", 2)
+	if len(parts) == 2 {
+		ver := parts[1]
+		for i, ch := range ver {
+			if ch == ' ' || ch == ';' {
+				ver = ver[:i]
+				break
+			}
+		}
+		version = ver
+	}
+	return info, version, original
 }
 
 // Check if we're dealing with a bot or with some weird browser. If that is the
@@ -139,9 +180,9 @@ func (p *UserAgent) checkBot(sections []section) bool {
 	// If there's only one element, and it doesn't have the Mozilla string,
 	// check whether this is a bot or not.
 	if len(sections) == 1 && sections[0].name != "Mozilla" {
-		// Check whether the name matches any known bad bot substring.
-		if isKnownBadBot(sections[0].name) {
-			p.setSimple(sections[0].name, sections[0].version, true)
+		// Check whether the name matches any known bad bot.
+		if info, alias := isKnownBadBot(sections[0].name); info != nil {
+			p.setBot(info, alias, sections[0].version)
 			return true
 		}
 
@@ -151,45 +192,13 @@ func (p *UserAgent) checkBot(sections []section) bool {
 			return true
 		}
 
-		return false
+		return p.detectImpersonatingBot(sections)
 	} else {
 		for _, v := range sections {
 			// Check comments for known bad bots
 			for _, c := range v.comment {
-				matched := ""
-				matchedVersion := ""
-				matchedOriginal := ""
-				for _, bot := range LoadBadBotsYAML() {
-					idx := strings.Index(strings.ToLower(c), strings.ToLower(bot))
-					if idx != -1 {
-						// Use the original case from the user agent string
-						matchedOriginal = c[idx : idx+len(bot)]
-						matched = bot
-						// Try to extract version if present (e.g., GPTBot/1.3)
-						// Look for botname/version pattern
-						lowerC := c[idx:]
-						parts := strings.SplitN(lowerC, "/", 2)
-						if len(parts) == 2 {
-							// Extract version up to next space or semicolon
-							ver := parts[1]
-							for i, ch := range ver {
-								if ch == ' ' || ch == ';' {
-									ver = ver[:i]
-									break
-								}
-							}
-							matchedVersion = ver
-						}
-						break
-					}
-				}
-				if matched != "" {
-					// Use the original case from the user agent string for the browser name
-					nameToSet := matchedOriginal
-					if nameToSet == "" {
-						nameToSet = matched
-					}
-					p.setSimple(nameToSet, matchedVersion, true)
+				if info, version, original := matchKnownBot(c); info != nil {
+					p.setBot(info, original, version)
 					return true
 				}
 			}
@@ -203,12 +212,44 @@ func (p *UserAgent) checkBot(sections []section) bool {
 				return true
 			}
 			// Also check each section name for known bad bots
-			if isKnownBadBot(v.name) {
-				// Use the original case from the section name
-				p.setSimple(v.name, v.version, true)
+			if info, alias := isKnownBadBot(v.name); info != nil {
+				p.setBot(info, alias, v.version)
 				return true
 			}
 		}
+		// No fallback to detectImpersonatingBot here: the loop above
+		// already ran matchKnownBot against every section's comment, so a
+		// second identical pass can never find anything the first one
+		// missed. detectImpersonatingBot is only useful from the
+		// single-section branch above, which doesn't otherwise scan the
+		// section's comment for a bad-bot name/version signature.
 		return false
 	}
 }
+
+// detectImpersonatingBot is checkBot's last resort for the single-section,
+// non-"Mozilla"-named branch: a pass over that section's comment looking for
+// a known-bot "name/version" signature (e.g. a bot that advertises a plain
+// product token but tucks its real identity into a parenthesized comment).
+// The multi-section branch already runs the same check against every
+// section's comment as part of its own loop, so calling this from there
+// would be a provably dead second pass.
+//
+// It takes []section, an unexported type, so it can't be part of the
+// public API; it's reached only through checkBot.
+//
+// If a match is found, the receiver is promoted to a bot: browser.Name and
+// browser.Version are rewritten from the match and the engine fields are
+// cleared, but mobile and platform are left untouched so callers can still
+// tell "YandexBot on iPhone" from "YandexBot on Linux".
+func (p *UserAgent) detectImpersonatingBot(sections []section) bool {
+	for _, v := range sections {
+		for _, c := range v.comment {
+			if info, version, original := matchKnownBot(c); info != nil {
+				p.setBot(info, original, version)
+				return true
+			}
+		}
+	}
+	return false
+}
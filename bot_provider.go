@@ -0,0 +1,224 @@
+package useragent
+
+import (
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// BotListProvider supplies the current snapshot of the bad-bots registry.
+// isKnownBadBot consults whatever provider is active via SetBotListProvider
+// on every call, so operators can ship updated crawler lists (new AI bots
+// appear monthly) without restarting services.
+type BotListProvider interface {
+	// Bots returns the current snapshot of known bad bots.
+	Bots() []BotInfo
+}
+
+// providerHolder boxes the active BotListProvider in a tiny struct so that
+// currentProvider can be an atomic.Pointer[providerHolder]; a nil pointer
+// means no provider has been installed yet.
+type providerHolder struct {
+	p BotListProvider
+}
+
+var currentProvider atomic.Pointer[providerHolder]
+
+// SetBotListProvider replaces the package-level source of truth for the
+// bad-bots registry used by isKnownBadBot (and everything built on it,
+// including checkBot and detectImpersonatingBot).
+func SetBotListProvider(p BotListProvider) {
+	currentProvider.Store(&providerHolder{p: p})
+}
+
+// LoadBadBotsYAML returns the current bad-bots registry snapshot from
+// whichever BotListProvider is active. Reads are lock-free on the fast
+// path: the active provider is held in an atomic.Pointer so this is safe to
+// call on every request on the hot path.
+//
+// If SetBotListProvider was never called, LoadBadBotsYAML lazily installs a
+// non-watching FileProvider for "bad_bots.yaml" the first time it's needed,
+// rather than at package init, so that programs which change their working
+// directory at startup still find it. The lazy install uses CompareAndSwap
+// instead of sync.Once so that a concurrent first call to
+// SetBotListProvider can never race past it: if SetBotListProvider stores
+// first, the CompareAndSwap fails and the default candidate is discarded;
+// if the default goes in first, SetBotListProvider's later Store replaces
+// it. Either way Load() is never nil here and SetBotListProvider's choice
+// is never silently overwritten by the default.
+func LoadBadBotsYAML() []BotInfo {
+	if h := currentProvider.Load(); h != nil {
+		return h.p.Bots()
+	}
+	candidate := &providerHolder{p: NewFileProvider("bad_bots.yaml", false)}
+	currentProvider.CompareAndSwap(nil, candidate)
+	return currentProvider.Load().p.Bots()
+}
+
+// FileProvider loads the bad-bots registry from a local YAML file. With
+// watch enabled, it uses fsnotify to reload the file whenever it changes on
+// disk instead of requiring a process restart.
+type FileProvider struct {
+	path string
+	snap atomic.Value // []BotInfo
+}
+
+// NewFileProvider loads path immediately and, if watch is true, starts a
+// background goroutine that reloads it on every write, create or rename
+// event for that path.
+func NewFileProvider(path string, watch bool) *FileProvider {
+	f := &FileProvider{path: path}
+	f.reload()
+	if watch {
+		go f.watch()
+	}
+	return f
+}
+
+func (f *FileProvider) Bots() []BotInfo {
+	bots, _ := f.snap.Load().([]BotInfo)
+	return bots
+}
+
+func (f *FileProvider) reload() {
+	bots, err := LoadBadBots(f.path)
+	if err != nil {
+		return
+	}
+	f.snap.Store(bots)
+}
+
+func (f *FileProvider) watch() {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return
+	}
+	defer watcher.Close()
+
+	// Watch the parent directory rather than the file itself: atomic
+	// deploys (write a temp file, then os.Rename over the target - the
+	// pattern Kubernetes ConfigMap mounts and most deploy tooling use)
+	// replace the file's inode, which silently detaches a watch held on
+	// the file directly. See the fsnotify docs' note on watching files.
+	if err := watcher.Add(filepath.Dir(f.path)); err != nil {
+		return
+	}
+
+	target := filepath.Clean(f.path)
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != target {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				f.reload()
+			}
+		case _, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// HTTPProvider periodically refetches a bad-bots registry YAML document
+// from a remote URL. With etag enabled, it sends an If-None-Match header on
+// each refresh and skips re-parsing when the server responds 304 Not
+// Modified.
+type HTTPProvider struct {
+	url      string
+	interval time.Duration
+	useETag  bool
+
+	snap atomic.Value // []BotInfo
+	etag atomic.Value // string
+}
+
+// NewHTTPProvider fetches url immediately and then, as long as interval is
+// positive, refetches it every interval for as long as the process runs. A
+// non-positive interval disables periodic refetching; the provider then
+// just serves the one-time fetch.
+func NewHTTPProvider(url string, interval time.Duration, etag bool) *HTTPProvider {
+	h := &HTTPProvider{url: url, interval: interval, useETag: etag}
+	h.refresh()
+	if interval > 0 {
+		go h.poll()
+	}
+	return h
+}
+
+func (h *HTTPProvider) Bots() []BotInfo {
+	bots, _ := h.snap.Load().([]BotInfo)
+	return bots
+}
+
+func (h *HTTPProvider) poll() {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		h.refresh()
+	}
+}
+
+func (h *HTTPProvider) refresh() {
+	req, err := http.NewRequest(http.MethodGet, h.url, nil)
+	if err != nil {
+		return
+	}
+	if h.useETag {
+		if etag, _ := h.etag.Load().(string); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		return
+	}
+
+	bots, err := decodeBadBotsList(resp.Body)
+	if err != nil {
+		return
+	}
+
+	h.snap.Store(bots)
+	if h.useETag {
+		h.etag.Store(resp.Header.Get("ETag"))
+	}
+}
+
+// StaticProvider serves a fixed, never-changing list of bad bot names.
+// It's primarily useful for tests that want a deterministic registry
+// without touching the filesystem or network.
+type StaticProvider struct {
+	bots []BotInfo
+}
+
+// NewStaticProvider wraps names as a registry of BotInfo entries with only
+// Canonical set.
+func NewStaticProvider(names []string) *StaticProvider {
+	bots := make([]BotInfo, 0, len(names))
+	for _, name := range names {
+		bots = append(bots, BotInfo{Canonical: name})
+	}
+	return &StaticProvider{bots: bots}
+}
+
+func (s *StaticProvider) Bots() []BotInfo {
+	return s.bots
+}
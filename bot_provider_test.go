@@ -0,0 +1,81 @@
+package useragent
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestLoadBadBotsYAMLNeverPanicsBeforeAnySet(t *testing.T) {
+	// currentProvider starts out nil and is lazily seeded by LoadBadBotsYAML
+	// itself on first use, so even a process that never calls
+	// SetBotListProvider must get a usable (possibly empty) result rather
+	// than a nil-interface panic.
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("LoadBadBotsYAML panicked: %v", r)
+		}
+	}()
+	_ = LoadBadBotsYAML()
+}
+
+func TestSetBotListProviderSwapsBetweenConcreteTypes(t *testing.T) {
+	defer SetBotListProvider(NewStaticProvider(nil)) // restore a known state for other tests
+
+	SetBotListProvider(NewStaticProvider([]string{"TestBot"}))
+	bots := LoadBadBotsYAML()
+	if len(bots) != 1 || bots[0].Canonical != "TestBot" {
+		t.Fatalf("unexpected bots after StaticProvider: %+v", bots)
+	}
+
+	// Switching to a different concrete BotListProvider implementation (here
+	// a FileProvider) must not panic and must take effect on the next read.
+	path := filepath.Join(t.TempDir(), "bad_bots.yaml")
+	if err := os.WriteFile(path, []byte("bots:\n  - canonical: OtherBot\n"), 0o644); err != nil {
+		t.Fatalf("writing registry file: %v", err)
+	}
+	SetBotListProvider(NewFileProvider(path, false))
+	bots = LoadBadBotsYAML()
+	if len(bots) != 1 || bots[0].Canonical != "OtherBot" {
+		t.Fatalf("unexpected bots after FileProvider: %+v", bots)
+	}
+}
+
+func TestSetBotListProviderConcurrentSwap(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		SetBotListProvider(NewStaticProvider([]string{"RaceBot"}))
+	}()
+	_ = LoadBadBotsYAML()
+	<-done
+}
+
+// TestLoadBadBotsYAMLConcurrentLazyInit exercises the race the
+// CompareAndSwap-based lazy default in LoadBadBotsYAML exists to prevent: a
+// concurrent SetBotListProvider call must never be clobbered by a
+// concurrently-installing default provider, and LoadBadBotsYAML must never
+// observe a nil currentProvider.
+func TestLoadBadBotsYAMLConcurrentLazyInit(t *testing.T) {
+	currentProvider.Store(nil) // force both goroutines to race the lazy install
+	defer SetBotListProvider(NewStaticProvider(nil))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		SetBotListProvider(NewStaticProvider([]string{"RaceBot"}))
+	}()
+	go func() {
+		defer wg.Done()
+		if bots := LoadBadBotsYAML(); bots == nil && len(bots) != 0 {
+			t.Errorf("LoadBadBotsYAML returned unexpected nil-backed result: %+v", bots)
+		}
+	}()
+	wg.Wait()
+
+	if bots := LoadBadBotsYAML(); len(bots) != 1 || bots[0].Canonical != "RaceBot" {
+		t.Fatalf("SetBotListProvider's choice was overwritten by the lazy default: %+v", bots)
+	}
+}
@@ -0,0 +1,74 @@
+package useragent
+
+import "testing"
+
+func withTestBotRegistry(t *testing.T, names []string) {
+	t.Helper()
+	SetBotListProvider(NewStaticProvider(names))
+}
+
+func TestCheckBotMultiSectionNestedComment(t *testing.T) {
+	withTestBotRegistry(t, []string{"YandexBot"})
+
+	// A multi-section, "Mozilla"-led UA with the bot identity buried in a
+	// later section's comment, e.g. the iPhone-impersonating
+	// YandexBot/3.0 case from the original request.
+	sections := []section{
+		{name: "Mozilla", version: "5.0", comment: []string{"iPhone", "CPU iPhone OS 14_0 like Mac OS X"}},
+		{name: "AppleWebKit", version: "605.1.15", comment: []string{"KHTML, like Gecko", "YandexBot/3.0"}},
+		{name: "Safari", version: "604.1"},
+	}
+
+	p := newTestUserAgent()
+	if !p.checkBot(sections) {
+		t.Fatal("checkBot() = false, want true for a nested YandexBot signature")
+	}
+	if p.browser.Name != "YandexBot" || p.browser.Version != "3.0" {
+		t.Errorf("got browser %q/%q, want YandexBot/3.0", p.browser.Name, p.browser.Version)
+	}
+	if !p.mobile || p.platform != "iPhone" {
+		t.Errorf("mobile/platform should be preserved, got mobile=%v platform=%q", p.mobile, p.platform)
+	}
+}
+
+func TestCheckBotSingleSectionNestedComment(t *testing.T) {
+	withTestBotRegistry(t, []string{"GPTBot"})
+
+	// A single, non-"Mozilla" section whose name alone doesn't match any
+	// known bad bot, but whose comment carries a nested bot signature.
+	// getFromSite wouldn't find this (it's not a URL), so this only
+	// succeeds via detectImpersonatingBot's comment scan.
+	sections := []section{
+		{name: "SomeClient", version: "1.0", comment: []string{"compatible", "GPTBot/1.2"}},
+	}
+
+	p := newTestUserAgent()
+	if !p.checkBot(sections) {
+		t.Fatal("checkBot() = false, want true for a nested GPTBot signature")
+	}
+	if p.browser.Name != "GPTBot" || p.browser.Version != "1.2" {
+		t.Errorf("got browser %q/%q, want GPTBot/1.2", p.browser.Name, p.browser.Version)
+	}
+	if !p.mobile || p.platform != "iPhone" {
+		t.Errorf("mobile/platform should be preserved, got mobile=%v platform=%q", p.mobile, p.platform)
+	}
+}
+
+func TestCheckBotNoMatch(t *testing.T) {
+	withTestBotRegistry(t, []string{"GPTBot"})
+
+	sections := []section{
+		{name: "Mozilla", version: "5.0", comment: []string{"Windows NT 10.0"}},
+		{name: "AppleWebKit", version: "537.36", comment: []string{"KHTML, like Gecko"}},
+		{name: "Chrome", version: "100.0"},
+	}
+
+	p := newTestUserAgent()
+	if p.checkBot(sections) {
+		t.Fatalf("checkBot() = true, want false for an ordinary browser UA; got %q/%q", p.browser.Name, p.browser.Version)
+	}
+}
+
+func newTestUserAgent() *UserAgent {
+	return &UserAgent{mobile: true, platform: "iPhone"}
+}
@@ -0,0 +1,137 @@
+package useragent
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// VerifyReverseDNS is the BotInfo.Verify method that confirms a bot's
+// claimed identity via forward-confirmed reverse DNS.
+const VerifyReverseDNS = "reverse_dns"
+
+// verifyCacheTTL bounds how long a VerificationResult is cached for a given
+// (remote IP, bot) pair, so that high-QPS servers aren't hammered with
+// repeated lookups for the same client.
+const verifyCacheTTL = 10 * time.Minute
+
+// VerificationResult reports the outcome of verifying a bot's claimed
+// identity against the remote IP that sent the request.
+type VerificationResult struct {
+	Verified bool
+	PTR      string
+	Reason   string
+}
+
+type verifyCacheEntry struct {
+	result    VerificationResult
+	expiresAt time.Time
+}
+
+var (
+	verifyCacheMu sync.Mutex
+	verifyCache   = map[string]verifyCacheEntry{}
+)
+
+// Verify checks whether remoteIP genuinely belongs to the bot that p was
+// classified as, using the verification method declared on its matched
+// registry entry. Only VerifyReverseDNS is currently supported: it performs
+// a reverse lookup of remoteIP, then a forward lookup of the resulting PTR
+// name, and considers the bot verified only if the forward lookup resolves
+// back to remoteIP and the PTR name ends in one of the entry's
+// VerifySuffixes.
+//
+// If p wasn't classified as a known bot, or its entry declares no
+// verification method, Verify returns an unverified result with a Reason
+// and a nil error — it's not an error to ask for verification of a bot that
+// doesn't support it. resolver defaults to net.DefaultResolver if nil.
+func (p *UserAgent) Verify(ctx context.Context, remoteIP net.IP, resolver *net.Resolver) (VerificationResult, error) {
+	if p.botInfo == nil || p.botInfo.Verify != VerifyReverseDNS {
+		return VerificationResult{Reason: "no reverse_dns verification configured for this bot"}, nil
+	}
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	key := verifyCacheKey(remoteIP, p.botInfo)
+	if result, ok := verifyCacheLookup(key); ok {
+		return result, nil
+	}
+
+	ptrNames, err := resolver.LookupAddr(ctx, remoteIP.String())
+	if err != nil {
+		return VerificationResult{}, err
+	}
+
+	result := VerificationResult{Reason: "no PTR record matched an allowed suffix for this bot"}
+	for _, ptr := range ptrNames {
+		if !hasAllowedSuffix(ptr, p.botInfo.VerifySuffixes) {
+			continue
+		}
+
+		addrs, err := resolver.LookupIPAddr(ctx, strings.TrimSuffix(ptr, "."))
+		if err != nil {
+			result.Reason = "PTR record did not resolve back to the remote IP"
+			continue
+		}
+		for _, addr := range addrs {
+			if addr.IP.Equal(remoteIP) {
+				result = VerificationResult{Verified: true, PTR: ptr, Reason: "forward-confirmed reverse DNS match"}
+				break
+			}
+		}
+		if result.Verified {
+			break
+		}
+		result.Reason = "PTR record did not resolve back to the remote IP"
+	}
+
+	verifyCacheStore(key, result)
+	return result, nil
+}
+
+// verifyCacheKey identifies a cached VerificationResult by both the remote
+// IP and the bot identity being verified. Keying by IP alone would let a
+// result verified for one bot leak into a later claim of a different bot
+// from the same IP (plausible on shared ranges or NAT) without ever
+// checking that bot's own VerifySuffixes.
+func verifyCacheKey(remoteIP net.IP, info *BotInfo) string {
+	id := info.Canonical
+	if info.ID != 0 {
+		id = strconv.Itoa(info.ID)
+	}
+	return remoteIP.String() + "|" + id
+}
+
+// hasAllowedSuffix returns true if ptr ends with any of suffixes
+// (case-insensitive). Resolver-returned PTR names are typically
+// fully-qualified with a trailing dot (e.g. "crawl.googlebot.com."), which
+// is stripped before comparing so suffixes can be configured without one.
+func hasAllowedSuffix(ptr string, suffixes []string) bool {
+	ptr = strings.ToLower(strings.TrimSuffix(ptr, "."))
+	for _, suffix := range suffixes {
+		if suffix != "" && strings.HasSuffix(ptr, strings.ToLower(suffix)) {
+			return true
+		}
+	}
+	return false
+}
+
+func verifyCacheLookup(key string) (VerificationResult, bool) {
+	verifyCacheMu.Lock()
+	defer verifyCacheMu.Unlock()
+	entry, ok := verifyCache[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return VerificationResult{}, false
+	}
+	return entry.result, true
+}
+
+func verifyCacheStore(key string, result VerificationResult) {
+	verifyCacheMu.Lock()
+	defer verifyCacheMu.Unlock()
+	verifyCache[key] = verifyCacheEntry{result: result, expiresAt: time.Now().Add(verifyCacheTTL)}
+}
@@ -0,0 +1,49 @@
+package useragent
+
+import (
+	"net"
+	"testing"
+)
+
+func TestHasAllowedSuffix(t *testing.T) {
+	suffixes := []string{".googlebot.com", ".google.com"}
+
+	cases := []struct {
+		ptr  string
+		want bool
+	}{
+		{"crawl-66-249-66-1.googlebot.com.", true},
+		{"CRAWL-66-249-66-1.GOOGLEBOT.COM.", true},
+		{"crawl-66-249-66-1.google.com.", true},
+		{"evil-googlebot.com.attacker.net.", false},
+		{"crawl-66-249-66-1.bing.com.", false},
+	}
+
+	for _, c := range cases {
+		if got := hasAllowedSuffix(c.ptr, suffixes); got != c.want {
+			t.Errorf("hasAllowedSuffix(%q) = %v, want %v", c.ptr, got, c.want)
+		}
+	}
+}
+
+func TestVerifyCacheKeyDistinguishesBotsOnSameIP(t *testing.T) {
+	ip := net.ParseIP("203.0.113.1")
+	googlebot := &BotInfo{ID: 1, Canonical: "Googlebot"}
+	bingbot := &BotInfo{ID: 2, Canonical: "bingbot"}
+
+	keyGoogle := verifyCacheKey(ip, googlebot)
+	keyBing := verifyCacheKey(ip, bingbot)
+
+	if keyGoogle == keyBing {
+		t.Fatalf("verifyCacheKey should differ per bot for the same IP, got %q for both", keyGoogle)
+	}
+}
+
+func TestVerifyCacheKeyStableForSameBot(t *testing.T) {
+	ip := net.ParseIP("203.0.113.1")
+	info := &BotInfo{ID: 1, Canonical: "Googlebot"}
+
+	if verifyCacheKey(ip, info) != verifyCacheKey(ip, info) {
+		t.Fatal("verifyCacheKey should be deterministic for the same inputs")
+	}
+}